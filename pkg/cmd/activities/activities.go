@@ -0,0 +1,126 @@
+package activities
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	jxv1 "github.com/jenkins-x/jx-api/v3/pkg/apis/jenkins.io/v1"
+	jxc "github.com/jenkins-x/jx-api/v3/pkg/client/clientset/versioned"
+	"github.com/jenkins-x/jx-helpers/v3/pkg/cobras/helper"
+	"github.com/jenkins-x/jx-helpers/v3/pkg/cobras/templates"
+	"github.com/jenkins-x/jx-helpers/v3/pkg/kube/jxclient"
+	"github.com/jenkins-x/jx-pipeline/pkg/output"
+	"github.com/jenkins-x/jx-pipeline/pkg/pipelineactivities"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/watch"
+
+	"github.com/spf13/cobra"
+)
+
+// Options contains the command line options
+type Options struct {
+	Namespace string
+	Watch     bool
+	Output    output.Options
+	JXClient  jxc.Interface
+}
+
+// activityEvent is the JSON shape emitted per state transition when --output jsonl is used
+type activityEvent struct {
+	Name   string `json:"name"`
+	Status string `json:"status"`
+}
+
+var (
+	cmdLong = templates.LongDesc(`
+		Displays the current activities for one or more pipelines
+`)
+
+	cmdExample = templates.Examples(`
+		# List the current activities
+		jx pipeline activities
+
+		# Stream activity state transitions as they happen
+		jx pipeline activities --watch --output jsonl
+	`)
+)
+
+// NewCmdActivities creates the command
+func NewCmdActivities() (*cobra.Command, *Options) {
+	o := &Options{}
+
+	cmd := &cobra.Command{
+		Use:     "activities",
+		Short:   "Displays the current activities for one or more pipelines",
+		Long:    cmdLong,
+		Example: cmdExample,
+		Run: func(cmd *cobra.Command, args []string) {
+			err := o.Run()
+			helper.CheckErr(err)
+		},
+	}
+	cmd.Flags().StringVarP(&o.Namespace, "namespace", "n", "", "The namespace to look for the activities. Defaults to the current namespace")
+	cmd.Flags().BoolVarP(&o.Watch, "watch", "w", false, "Watches and streams activity state transitions instead of printing a snapshot")
+	o.Output.AddFlag(cmd)
+
+	return cmd, o
+}
+
+// Validate verifies things are setup correctly
+func (o *Options) Validate() error {
+	var err error
+	o.JXClient, err = jxclient.LazyCreateJXClient(o.JXClient)
+	if err != nil {
+		return errors.Wrapf(err, "failed to create jx client")
+	}
+	return o.Output.Validate()
+}
+
+// Run implements this command
+func (o *Options) Run() error {
+	err := o.Validate()
+	if err != nil {
+		return errors.Wrapf(err, "failed to validate options")
+	}
+
+	if o.Watch {
+		return o.watchActivities()
+	}
+	return o.listActivities()
+}
+
+func (o *Options) listActivities() error {
+	return pipelineactivities.ListAndWrite(context.Background(), o.JXClient, o.Namespace, os.Stdout, o.Output)
+}
+
+// watchActivities streams every PipelineActivity add/update as a JSON event, one per line, so it
+// can be piped into jq or a log shipper
+func (o *Options) watchActivities() error {
+	ctx := context.Background()
+	w, err := o.JXClient.JenkinsV1().PipelineActivities(o.Namespace).Watch(ctx, metav1.ListOptions{})
+	if err != nil {
+		return errors.Wrapf(err, "failed to watch PipelineActivities in namespace %s", o.Namespace)
+	}
+	defer w.Stop()
+
+	for event := range w.ResultChan() {
+		activity, ok := event.Object.(*jxv1.PipelineActivity)
+		if !ok {
+			continue
+		}
+		if event.Type == watch.Deleted {
+			continue
+		}
+
+		if output.Format(o.Output.Format) == output.JSONLFormat {
+			if err := o.Output.WriteLine(os.Stdout, activityEvent{Name: activity.Name, Status: string(activity.Spec.Status)}); err != nil {
+				return errors.Wrapf(err, "failed to write activity event")
+			}
+			continue
+		}
+		fmt.Printf("%s\t%s\n", activity.Name, activity.Spec.Status)
+	}
+	return nil
+}
@@ -0,0 +1,74 @@
+package get
+
+import (
+	"context"
+	"os"
+
+	jxc "github.com/jenkins-x/jx-api/v3/pkg/client/clientset/versioned"
+	"github.com/jenkins-x/jx-helpers/v3/pkg/cobras/helper"
+	"github.com/jenkins-x/jx-helpers/v3/pkg/cobras/templates"
+	"github.com/jenkins-x/jx-helpers/v3/pkg/kube/jxclient"
+	"github.com/jenkins-x/jx-pipeline/pkg/output"
+	"github.com/jenkins-x/jx-pipeline/pkg/pipelineactivities"
+	"github.com/pkg/errors"
+
+	"github.com/spf13/cobra"
+)
+
+// Options contains the command line options
+type Options struct {
+	Namespace string
+	Output    output.Options
+	JXClient  jxc.Interface
+}
+
+var (
+	cmdLong = templates.LongDesc(`
+		Displays the Pipelines and their associated activities
+`)
+
+	cmdExample = templates.Examples(`
+		# List all the current pipelines
+		jx pipeline get
+	`)
+)
+
+// NewCmdPipelineGet creates the command
+func NewCmdPipelineGet() (*cobra.Command, *Options) {
+	o := &Options{}
+
+	cmd := &cobra.Command{
+		Use:     "get",
+		Short:   "Displays the Pipelines and their associated activities",
+		Long:    cmdLong,
+		Example: cmdExample,
+		Run: func(cmd *cobra.Command, args []string) {
+			err := o.Run()
+			helper.CheckErr(err)
+		},
+	}
+	cmd.Flags().StringVarP(&o.Namespace, "namespace", "n", "", "The namespace to look for the activities. Defaults to the current namespace")
+	o.Output.AddFlag(cmd)
+
+	return cmd, o
+}
+
+// Validate verifies things are setup correctly
+func (o *Options) Validate() error {
+	var err error
+	o.JXClient, err = jxclient.LazyCreateJXClient(o.JXClient)
+	if err != nil {
+		return errors.Wrapf(err, "failed to create jx client")
+	}
+	return o.Output.Validate()
+}
+
+// Run implements this command
+func (o *Options) Run() error {
+	err := o.Validate()
+	if err != nil {
+		return errors.Wrapf(err, "failed to validate options")
+	}
+
+	return pipelineactivities.ListAndWrite(context.Background(), o.JXClient, o.Namespace, os.Stdout, o.Output)
+}
@@ -0,0 +1,139 @@
+package getlog
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/jenkins-x/jx-helpers/v3/pkg/cobras/helper"
+	"github.com/jenkins-x/jx-helpers/v3/pkg/cobras/templates"
+	"github.com/jenkins-x/jx-helpers/v3/pkg/kube"
+	"github.com/jenkins-x/jx-helpers/v3/pkg/options"
+	"github.com/jenkins-x/jx-pipeline/pkg/output"
+	"github.com/jenkins-x/jx-pipeline/pkg/tektonclient"
+	"github.com/jenkins-x/jx-pipeline/pkg/tektonlog"
+	"github.com/pkg/errors"
+	tektonapiclient "github.com/tektoncd/pipeline/pkg/client/clientset/versioned"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/spf13/cobra"
+)
+
+// Options contains the command line options
+type Options struct {
+	Namespace    string
+	Name         string
+	Follow       bool
+	Output       output.Options
+	KubeClient   kubernetes.Interface
+	TektonClient tektonapiclient.Interface
+}
+
+// logLine is the JSON shape emitted per log line when --output jsonl is used
+type logLine struct {
+	Time        string `json:"ts"`
+	PipelineRun string `json:"pipelinerun"`
+	Task        string `json:"task"`
+	Step        string `json:"step"`
+	Pod         string `json:"pod"`
+	Container   string `json:"container"`
+	Message     string `json:"message"`
+	Level       string `json:"level"`
+}
+
+var (
+	cmdLong = templates.LongDesc(`
+		Displays the build logs for a pipeline
+`)
+
+	cmdExample = templates.Examples(`
+		# print the logs for the given PipelineRun
+		jx pipeline logs --name mypr-myrepo-pr-42-1
+
+		# follow the logs for the given PipelineRun as it runs
+		jx pipeline logs --name mypr-myrepo-pr-42-1 -f
+	`)
+)
+
+// NewCmdGetBuildLogs creates the command
+func NewCmdGetBuildLogs() (*cobra.Command, *Options) {
+	o := &Options{}
+
+	cmd := &cobra.Command{
+		Use:     "logs",
+		Short:   "Displays the build logs for a pipeline",
+		Long:    cmdLong,
+		Example: cmdExample,
+		Aliases: []string{"log"},
+		Run: func(cmd *cobra.Command, args []string) {
+			err := o.Run()
+			helper.CheckErr(err)
+		},
+	}
+	cmd.Flags().StringVarP(&o.Namespace, "namespace", "n", "", "The namespace to look for the PipelineRun. Defaults to the current namespace")
+	cmd.Flags().StringVarP(&o.Name, "name", "", "", "The name of the PipelineRun to view the logs of")
+	cmd.Flags().BoolVarP(&o.Follow, "follow", "f", false, "Tails the logs of the PipelineRun as it runs, across all of its TaskRuns and steps")
+	o.Output.AddFlag(cmd)
+
+	return cmd, o
+}
+
+// Validate verifies things are setup correctly
+func (o *Options) Validate() error {
+	var err error
+	o.KubeClient, o.Namespace, err = kube.LazyCreateKubeClientAndNamespace(o.KubeClient, o.Namespace)
+	if err != nil {
+		return errors.Wrapf(err, "failed to create kube client")
+	}
+	o.TektonClient, err = tektonclient.LazyCreateTektonClient(o.TektonClient)
+	if err != nil {
+		return errors.Wrapf(err, "failed to create tekton client")
+	}
+	if o.Name == "" {
+		return options.MissingOption("name")
+	}
+	return o.Output.Validate()
+}
+
+// Run implements this command
+func (o *Options) Run() error {
+	err := o.Validate()
+	if err != nil {
+		return errors.Wrapf(err, "failed to validate options")
+	}
+	return o.tailLogs()
+}
+
+// tailLogs streams the PipelineRun's logs to stdout. Without --follow, the TaskRuns and steps
+// already present are read to completion and the command returns once that snapshot has printed;
+// with --follow it keeps streaming as new TaskRuns are scheduled and new lines are produced.
+func (o *Options) tailLogs() error {
+	ctx := context.Background()
+	entries, err := tektonlog.TailPipelineRunLogs(ctx, o.TektonClient, o.KubeClient, o.Namespace, o.Name, tektonlog.TailOptions{
+		Follow: o.Follow,
+	})
+	if err != nil {
+		return errors.Wrapf(err, "failed to tail logs for PipelineRun %s", o.Name)
+	}
+
+	for entry := range entries {
+		if output.Format(o.Output.Format) == output.JSONLFormat {
+			line := logLine{
+				Time:        entry.Time.Format("2006-01-02T15:04:05.000Z07:00"),
+				PipelineRun: o.Name,
+				Task:        entry.Task,
+				Step:        entry.Step,
+				Pod:         entry.Pod,
+				Container:   entry.Container,
+				Message:     entry.Line,
+				Level:       string(entry.Level),
+			}
+			if err := o.Output.WriteLine(os.Stdout, line); err != nil {
+				return errors.Wrapf(err, "failed to write log line")
+			}
+			continue
+		}
+		fmt.Printf("[%s:%s] %s\n", entry.Task, entry.Step, entry.Line)
+	}
+	return nil
+}
@@ -0,0 +1,110 @@
+package pod
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/jenkins-x/jx-helpers/v3/pkg/cobras/helper"
+	"github.com/jenkins-x/jx-helpers/v3/pkg/cobras/templates"
+	"github.com/jenkins-x/jx-helpers/v3/pkg/kube"
+	"github.com/jenkins-x/jx-helpers/v3/pkg/options"
+	"github.com/jenkins-x/jx-pipeline/pkg/output"
+	"github.com/jenkins-x/jx-pipeline/pkg/tektonclient"
+	"github.com/jenkins-x/jx-pipeline/pkg/tektonlog"
+	"github.com/pkg/errors"
+	tektonapiclient "github.com/tektoncd/pipeline/pkg/client/clientset/versioned"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/spf13/cobra"
+)
+
+// Options contains the command line options
+type Options struct {
+	Namespace    string
+	Name         string
+	Output       output.Options
+	KubeClient   kubernetes.Interface
+	TektonClient tektonapiclient.Interface
+}
+
+var (
+	cmdLong = templates.LongDesc(`
+		Displays the build pods for a pipeline
+`)
+
+	cmdExample = templates.Examples(`
+		# List the pods for the given PipelineRun
+		jx pipeline pod --name mypr-myrepo-pr-42-1
+	`)
+)
+
+// NewCmdGetBuildPods creates the command
+func NewCmdGetBuildPods() (*cobra.Command, *Options) {
+	o := &Options{}
+
+	cmd := &cobra.Command{
+		Use:     "pod",
+		Short:   "Displays the build pods for a pipeline",
+		Long:    cmdLong,
+		Example: cmdExample,
+		Aliases: []string{"pods"},
+		Run: func(cmd *cobra.Command, args []string) {
+			err := o.Run()
+			helper.CheckErr(err)
+		},
+	}
+	cmd.Flags().StringVarP(&o.Namespace, "namespace", "n", "", "The namespace to look for the PipelineRun. Defaults to the current namespace")
+	cmd.Flags().StringVarP(&o.Name, "name", "", "", "The name of the PipelineRun to list the pods of")
+	o.Output.AddFlag(cmd)
+
+	return cmd, o
+}
+
+// Validate verifies things are setup correctly
+func (o *Options) Validate() error {
+	var err error
+	o.KubeClient, o.Namespace, err = kube.LazyCreateKubeClientAndNamespace(o.KubeClient, o.Namespace)
+	if err != nil {
+		return errors.Wrapf(err, "failed to create kube client")
+	}
+	o.TektonClient, err = tektonclient.LazyCreateTektonClient(o.TektonClient)
+	if err != nil {
+		return errors.Wrapf(err, "failed to create tekton client")
+	}
+	if o.Name == "" {
+		return options.MissingOption("name")
+	}
+	return o.Output.Validate()
+}
+
+// Run implements this command
+func (o *Options) Run() error {
+	err := o.Validate()
+	if err != nil {
+		return errors.Wrapf(err, "failed to validate options")
+	}
+
+	// a single named lookup, so list directly against the API rather than paying for the
+	// informer-backed PipelineRunInfo builder's full-namespace sync
+	pods, err := o.KubeClient.CoreV1().Pods(o.Namespace).List(context.Background(), metav1.ListOptions{
+		LabelSelector: tektonlog.LabelPipelineRunName + "=" + o.Name,
+	})
+	if err != nil {
+		return errors.Wrapf(err, "failed to list pods for PipelineRun %s in namespace %s", o.Name, o.Namespace)
+	}
+
+	if !o.Output.IsTable() {
+		names := make([]string, 0, len(pods.Items))
+		for _, pod := range pods.Items {
+			names = append(names, pod.Name)
+		}
+		return o.Output.WriteObject(os.Stdout, names)
+	}
+
+	for _, pod := range pods.Items {
+		fmt.Println(pod.Name)
+	}
+	return nil
+}
@@ -0,0 +1,311 @@
+package rerun
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/ghodss/yaml"
+	"github.com/jenkins-x/go-scm/scm"
+	"github.com/jenkins-x/go-scm/scm/factory"
+	jxc "github.com/jenkins-x/jx-api/v3/pkg/client/clientset/versioned"
+	"github.com/jenkins-x/jx-helpers/v3/pkg/cobras/helper"
+	"github.com/jenkins-x/jx-helpers/v3/pkg/cobras/templates"
+	"github.com/jenkins-x/jx-helpers/v3/pkg/kube"
+	"github.com/jenkins-x/jx-helpers/v3/pkg/kube/jxclient"
+	"github.com/jenkins-x/jx-helpers/v3/pkg/options"
+	"github.com/jenkins-x/jx-helpers/v3/pkg/termcolor"
+	"github.com/jenkins-x/jx-logging/v3/pkg/log"
+	"github.com/jenkins-x/jx-pipeline/pkg/tektonclient"
+	"github.com/jenkins-x/jx-pipeline/pkg/tektonlog"
+	"github.com/pkg/errors"
+	pipelineapi "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	tektonapiclient "github.com/tektoncd/pipeline/pkg/client/clientset/versioned"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/kubernetes"
+	"knative.dev/pkg/apis"
+
+	"github.com/spf13/cobra"
+)
+
+// labels copied from a source PipelineRun onto a rerun, used by Lighthouse to correlate
+// the activity back to the originating PR/branch.
+const (
+	labelOwner      = "lighthouse.jenkins-x.io/owner"
+	labelRepository = "lighthouse.jenkins-x.io/repo"
+	labelBranch     = "lighthouse.jenkins-x.io/branch"
+	labelContext    = "lighthouse.jenkins-x.io/context"
+	labelLastCommit = "lighthouse.jenkins-x.io/lastCommitSHA"
+	labelPullNumber = "lighthouse.jenkins-x.io/refs.pull"
+)
+
+// Options contains the command line options
+type Options struct {
+	Namespace          string
+	Sha                string
+	PullRequest        string
+	Name               string
+	DryRun             bool
+	EnableStatusUpdate bool
+	KubeClient         kubernetes.Interface
+	TektonClient       tektonapiclient.Interface
+	JXClient           jxc.Interface
+	ScmClientFactory   func(owner, repository string) (*scm.Client, error)
+}
+
+var (
+	info = termcolor.ColorInfo
+
+	cmdLong = templates.LongDesc(`
+		Reruns a completed PipelineRun by cloning its spec into a new PipelineRun
+
+		The new PipelineRun reuses the params, resources, workspaces and service account of the
+		source run and carries over the Lighthouse labels so the resulting activity is correlated
+		to the original PR/branch.
+`)
+
+	cmdExample = templates.Examples(`
+		# rerun the pipeline for the given commit sha
+		jx pipeline rerun --sha abc1234
+
+		# rerun the pipeline for a pull request
+		jx pipeline rerun --pr 42
+
+		# rerun a specific PipelineRun by name
+		jx pipeline rerun --name mypr-myrepo-pr-42-1
+
+		# preview the PipelineRun that would be created
+		jx pipeline rerun --name mypr-myrepo-pr-42-1 --dry-run
+	`)
+)
+
+// NewCmdPipelineRerun creates the command
+func NewCmdPipelineRerun() (*cobra.Command, *Options) {
+	o := &Options{}
+
+	cmd := &cobra.Command{
+		Use:     "rerun",
+		Short:   "Reruns a completed PipelineRun",
+		Long:    cmdLong,
+		Example: cmdExample,
+		Run: func(cmd *cobra.Command, args []string) {
+			err := o.Run()
+			helper.CheckErr(err)
+		},
+	}
+	cmd.Flags().StringVarP(&o.Namespace, "namespace", "n", "", "The namespace to look for the PipelineRun. Defaults to the current namespace")
+	cmd.Flags().StringVarP(&o.Sha, "sha", "", "", "Selects the PipelineRun for the given commit sha to rerun")
+	cmd.Flags().StringVarP(&o.PullRequest, "pr", "", "", "Selects the PipelineRun for the given pull request number to rerun")
+	cmd.Flags().StringVarP(&o.Name, "name", "", "", "The name of the PipelineRun to rerun")
+	cmd.Flags().BoolVarP(&o.DryRun, "dry-run", "", false, "Print the YAML for the rerun PipelineRun instead of creating it")
+	cmd.Flags().BoolVarP(&o.EnableStatusUpdate, "enable-rerun-status-update", "", true, "Push a pending commit status to the git provider and update it as the rerun progresses")
+
+	return cmd, o
+}
+
+// Validate verifies things are setup correctly
+func (o *Options) Validate() error {
+	var err error
+	o.KubeClient, o.Namespace, err = kube.LazyCreateKubeClientAndNamespace(o.KubeClient, o.Namespace)
+	if err != nil {
+		return errors.Wrapf(err, "failed to create kube client")
+	}
+	o.JXClient, err = jxclient.LazyCreateJXClient(o.JXClient)
+	if err != nil {
+		return errors.Wrapf(err, "failed to create jx client")
+	}
+	o.TektonClient, err = tektonclient.LazyCreateTektonClient(o.TektonClient)
+	if err != nil {
+		return errors.Wrapf(err, "failed to create tekton client")
+	}
+
+	if o.Name == "" && o.Sha == "" && o.PullRequest == "" {
+		return options.MissingOption("name, sha or pr")
+	}
+
+	if o.ScmClientFactory == nil {
+		o.ScmClientFactory = func(owner, repository string) (*scm.Client, error) {
+			return factory.NewClient("", "", "")
+		}
+	}
+	return nil
+}
+
+// Run implements this command
+func (o *Options) Run() error {
+	err := o.Validate()
+	if err != nil {
+		return errors.Wrapf(err, "failed to validate options")
+	}
+
+	ctx := context.Background()
+	source, err := o.findSourcePipelineRun(ctx)
+	if err != nil {
+		return errors.Wrapf(err, "failed to find the PipelineRun to rerun")
+	}
+
+	rerun := o.clonePipelineRun(source)
+
+	if o.DryRun {
+		data, err := yaml.Marshal(rerun)
+		if err != nil {
+			return errors.Wrapf(err, "failed to marshal PipelineRun %s to YAML", rerun.Name)
+		}
+		fmt.Println(string(data))
+		return nil
+	}
+
+	created, err := o.TektonClient.TektonV1beta1().PipelineRuns(o.Namespace).Create(ctx, rerun, metav1.CreateOptions{})
+	if err != nil {
+		return errors.Wrapf(err, "failed to create rerun PipelineRun in namespace %s", o.Namespace)
+	}
+	log.Logger().Infof("created rerun PipelineRun %s in namespace %s", info(created.Name), info(o.Namespace))
+
+	if o.EnableStatusUpdate {
+		if err := o.updateCommitStatus(ctx, created, "pending", "rerunning the pipeline"); err != nil {
+			log.Logger().Warnf("failed to update commit status for rerun %s: %s", created.Name, err.Error())
+		}
+		if err := o.watchAndFinalizeCommitStatus(ctx, created); err != nil {
+			log.Logger().Warnf("failed to update final commit status for rerun %s: %s", created.Name, err.Error())
+		}
+	}
+	return nil
+}
+
+// watchAndFinalizeCommitStatus polls the rerun PipelineRun until it reaches a terminal state, then
+// pushes the matching "success" or "failure" commit status, mirroring the Lighthouse
+// tekton-controller's enableRerunStatusUpdate behaviour of updating the status as the rerun
+// progresses rather than leaving it stuck on "pending"
+func (o *Options) watchAndFinalizeCommitStatus(ctx context.Context, pr *pipelineapi.PipelineRun) error {
+	for {
+		current, err := o.TektonClient.TektonV1beta1().PipelineRuns(pr.Namespace).Get(ctx, pr.Name, metav1.GetOptions{})
+		if err != nil {
+			return errors.Wrapf(err, "failed to get PipelineRun %s", pr.Name)
+		}
+		if tektonlog.PipelineRunIsComplete(current) {
+			state := "failure"
+			description := "the rerun pipeline failed"
+			condition := current.Status.GetCondition(apis.ConditionSucceeded)
+			if condition != nil && condition.IsTrue() {
+				state = "success"
+				description = "the rerun pipeline succeeded"
+			}
+			return o.updateCommitStatus(ctx, current, state, description)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(time.Second * 5):
+		}
+	}
+}
+
+// findSourcePipelineRun locates the PipelineRun to clone based on the --name, --sha or --pr selector
+func (o *Options) findSourcePipelineRun(ctx context.Context) (*pipelineapi.PipelineRun, error) {
+	if o.Name != "" {
+		return o.TektonClient.TektonV1beta1().PipelineRuns(o.Namespace).Get(ctx, o.Name, metav1.GetOptions{})
+	}
+
+	selector := labels.NewSelector()
+	if o.Sha != "" {
+		req, err := labels.NewRequirement(labelLastCommit, "==", []string{o.Sha})
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to build selector for sha %s", o.Sha)
+		}
+		selector = selector.Add(*req)
+	}
+	if o.PullRequest != "" {
+		req, err := labels.NewRequirement(labelPullNumber, "==", []string{o.PullRequest})
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to build selector for pull request %s", o.PullRequest)
+		}
+		selector = selector.Add(*req)
+	}
+
+	list, err := o.TektonClient.TektonV1beta1().PipelineRuns(o.Namespace).List(ctx, metav1.ListOptions{LabelSelector: selector.String()})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to list PipelineRuns in namespace %s matching %s", o.Namespace, selector.String())
+	}
+	if len(list.Items) == 0 {
+		return nil, errors.Errorf("no PipelineRun found in namespace %s matching %s", o.Namespace, selector.String())
+	}
+
+	// pick the most recently created match
+	latest := list.Items[0]
+	for _, pr := range list.Items[1:] {
+		if pr.CreationTimestamp.After(latest.CreationTimestamp.Time) {
+			latest = pr
+		}
+	}
+	return &latest, nil
+}
+
+// clonePipelineRun builds a new PipelineRun from source, reusing its spec and Lighthouse labels
+// but with a generated name so it is tracked as a distinct PipelineActivity
+func (o *Options) clonePipelineRun(source *pipelineapi.PipelineRun) *pipelineapi.PipelineRun {
+	rerun := &pipelineapi.PipelineRun{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: source.Name + "-rerun-",
+			Namespace:    source.Namespace,
+			Labels:       copyMap(source.Labels),
+			Annotations:  copyMap(source.Annotations),
+		},
+		Spec: source.Spec,
+	}
+	rerun.Spec.Status = ""
+	return rerun
+}
+
+// updateCommitStatus pushes a commit status to the git provider for the PipelineRun, mirroring
+// the Lighthouse tekton-controller's enableRerunStatusUpdate behaviour
+func (o *Options) updateCommitStatus(ctx context.Context, pr *pipelineapi.PipelineRun, state, description string) error {
+	owner := pr.Labels[labelOwner]
+	repository := pr.Labels[labelRepository]
+	sha := pr.Labels[labelLastCommit]
+	if owner == "" || repository == "" || sha == "" {
+		return errors.Errorf("PipelineRun %s is missing the %s/%s/%s labels required to update the commit status", pr.Name, labelOwner, labelRepository, labelLastCommit)
+	}
+
+	client, err := o.ScmClientFactory(owner, repository)
+	if err != nil {
+		return errors.Wrapf(err, "failed to create git provider client for %s/%s", owner, repository)
+	}
+
+	input := &scm.StatusInput{
+		State:  toScmState(state),
+		Label:  pr.Labels[labelContext],
+		Desc:   description,
+		Target: "",
+	}
+	_, _, err = client.Repositories.CreateStatus(ctx, scm.Join(owner, repository), sha, input)
+	if err != nil {
+		return errors.Wrapf(err, "failed to create commit status on %s/%s@%s", owner, repository, sha)
+	}
+	return nil
+}
+
+func toScmState(state string) scm.State {
+	switch state {
+	case "pending":
+		return scm.StatePending
+	case "success":
+		return scm.StateSuccess
+	case "failure":
+		return scm.StateFailure
+	default:
+		return scm.StateError
+	}
+}
+
+func copyMap(in map[string]string) map[string]string {
+	if in == nil {
+		return nil
+	}
+	out := make(map[string]string, len(in))
+	for k, v := range in {
+		out[k] = v
+	}
+	return out
+}
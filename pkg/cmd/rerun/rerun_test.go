@@ -0,0 +1,72 @@
+package rerun
+
+import (
+	"testing"
+
+	"github.com/jenkins-x/go-scm/scm"
+	pipelineapi "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestClonePipelineRun(t *testing.T) {
+	o := &Options{}
+	source := &pipelineapi.PipelineRun{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "mypr-myrepo-pr-42-1",
+			Namespace:   "jx",
+			Labels:      map[string]string{labelLastCommit: "abc1234"},
+			Annotations: map[string]string{"foo": "bar"},
+		},
+		Spec: pipelineapi.PipelineRunSpec{
+			Status: pipelineapi.PipelineRunSpecStatusCancelled,
+		},
+	}
+
+	rerun := o.clonePipelineRun(source)
+
+	if rerun.GenerateName != "mypr-myrepo-pr-42-1-rerun-" {
+		t.Errorf("expected GenerateName %q, got %q", "mypr-myrepo-pr-42-1-rerun-", rerun.GenerateName)
+	}
+	if rerun.Name != "" {
+		t.Errorf("expected no fixed Name so a new one is generated, got %q", rerun.Name)
+	}
+	if rerun.Namespace != source.Namespace {
+		t.Errorf("expected namespace %q, got %q", source.Namespace, rerun.Namespace)
+	}
+	if rerun.Labels[labelLastCommit] != "abc1234" {
+		t.Errorf("expected labels to be copied from source, got %v", rerun.Labels)
+	}
+	if rerun.Annotations["foo"] != "bar" {
+		t.Errorf("expected annotations to be copied from source, got %v", rerun.Annotations)
+	}
+	if rerun.Spec.Status != "" {
+		t.Errorf("expected the rerun's Status to be cleared so it actually runs, got %q", rerun.Spec.Status)
+	}
+
+	// mutating the clone's labels must not affect the source
+	rerun.Labels["mutated"] = "true"
+	if _, ok := source.Labels["mutated"]; ok {
+		t.Errorf("expected clonePipelineRun to deep copy labels, but source was mutated")
+	}
+}
+
+func TestToScmState(t *testing.T) {
+	tests := []struct {
+		name  string
+		state string
+		want  scm.State
+	}{
+		{"pending", "pending", scm.StatePending},
+		{"success", "success", scm.StateSuccess},
+		{"failure", "failure", scm.StateFailure},
+		{"unknown falls back to error", "bogus", scm.StateError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := toScmState(tt.state); got != tt.want {
+				t.Errorf("toScmState(%q) = %v, want %v", tt.state, got, tt.want)
+			}
+		})
+	}
+}
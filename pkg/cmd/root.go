@@ -7,6 +7,7 @@ import (
 	"github.com/jenkins-x/jx-pipeline/pkg/cmd/get"
 	"github.com/jenkins-x/jx-pipeline/pkg/cmd/getlog"
 	"github.com/jenkins-x/jx-pipeline/pkg/cmd/pod"
+	"github.com/jenkins-x/jx-pipeline/pkg/cmd/rerun"
 	"github.com/jenkins-x/jx-pipeline/pkg/cmd/start"
 	"github.com/jenkins-x/jx-pipeline/pkg/cmd/stop"
 	"github.com/jenkins-x/jx-pipeline/pkg/cmd/version"
@@ -32,6 +33,7 @@ func Main() *cobra.Command {
 	cmd.AddCommand(cobras.SplitCommand(get.NewCmdPipelineGet()))
 	cmd.AddCommand(cobras.SplitCommand(getlog.NewCmdGetBuildLogs()))
 	cmd.AddCommand(cobras.SplitCommand(pod.NewCmdGetBuildPods()))
+	cmd.AddCommand(cobras.SplitCommand(rerun.NewCmdPipelineRerun()))
 	cmd.AddCommand(cobras.SplitCommand(start.NewCmdPipelineStart()))
 	cmd.AddCommand(cobras.SplitCommand(stop.NewCmdPipelineStop()))
 	cmd.AddCommand(cobras.SplitCommand(wait.NewCmdPipelineWait()))
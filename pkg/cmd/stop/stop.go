@@ -0,0 +1,148 @@
+package stop
+
+import (
+	"context"
+	"time"
+
+	"github.com/jenkins-x/jx-helpers/v3/pkg/cobras/helper"
+	"github.com/jenkins-x/jx-helpers/v3/pkg/cobras/templates"
+	"github.com/jenkins-x/jx-helpers/v3/pkg/kube"
+	"github.com/jenkins-x/jx-helpers/v3/pkg/options"
+	"github.com/jenkins-x/jx-helpers/v3/pkg/termcolor"
+	"github.com/jenkins-x/jx-logging/v3/pkg/log"
+	"github.com/jenkins-x/jx-pipeline/pkg/tektonclient"
+	"github.com/jenkins-x/jx-pipeline/pkg/tektonlog"
+	"github.com/pkg/errors"
+	pipelineapi "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	tektonapiclient "github.com/tektoncd/pipeline/pkg/client/clientset/versioned"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/spf13/cobra"
+)
+
+// modeCancel cancels the PipelineRun immediately, skipping any remaining tasks
+const modeCancel = "cancel"
+
+// modeStop requests the PipelineRun stop once its currently running TaskRuns finish, without
+// starting any new ones
+const modeStop = "stop"
+
+// modeCancelRunFinally cancels the PipelineRun but lets its finally tasks run to completion
+const modeCancelRunFinally = "cancel-run-finally"
+
+// Options contains the command line options
+type Options struct {
+	Name         string
+	Namespace    string
+	Reason       string
+	GracePeriod  time.Duration
+	Mode         string
+	Cascade      bool
+	KubeClient   kubernetes.Interface
+	TektonClient tektonapiclient.Interface
+}
+
+var (
+	info = termcolor.ColorInfo
+
+	cmdLong = templates.LongDesc(`
+		Stops or cancels a pipeline
+`)
+
+	cmdExample = templates.Examples(`
+		# cancel a pipeline immediately
+		jx pipeline stop --name mypr-myrepo-pr-42-1 --reason "bad commit"
+
+		# stop a pipeline once its running tasks finish, force-deleting any pods still
+		# running after 30s
+		jx pipeline stop --name mypr-myrepo-pr-42-1 --mode stop --grace 30s
+	`)
+)
+
+// NewCmdPipelineStop creates the command
+func NewCmdPipelineStop() (*cobra.Command, *Options) {
+	o := &Options{}
+
+	cmd := &cobra.Command{
+		Use:     "stop",
+		Short:   "Stops or cancels a pipeline",
+		Long:    cmdLong,
+		Example: cmdExample,
+		Aliases: []string{"cancel"},
+		Run: func(cmd *cobra.Command, args []string) {
+			err := o.Run()
+			helper.CheckErr(err)
+		},
+	}
+	cmd.Flags().StringVarP(&o.Name, "name", "", "", "The name of the PipelineRun to stop")
+	cmd.Flags().StringVarP(&o.Namespace, "namespace", "n", "", "The namespace to look for the PipelineRun. Defaults to the current namespace")
+	cmd.Flags().StringVarP(&o.Reason, "reason", "", "", "A human readable reason to record on the PipelineRun for why it was stopped")
+	cmd.Flags().DurationVarP(&o.GracePeriod, "grace", "", 0, "How long to wait for the PipelineRun to reach a terminal state before force-deleting any pods still running")
+	cmd.Flags().StringVarP(&o.Mode, "mode", "", modeCancel, "How to stop the PipelineRun, one of: cancel, stop, cancel-run-finally")
+	cmd.Flags().BoolVarP(&o.Cascade, "cascade", "", true, "Also explicitly cancel the PipelineRun's TaskRuns, for controller versions that don't propagate cancellation to them")
+
+	return cmd, o
+}
+
+// Validate verifies things are setup correctly
+func (o *Options) Validate() error {
+	var err error
+	o.KubeClient, o.Namespace, err = kube.LazyCreateKubeClientAndNamespace(o.KubeClient, o.Namespace)
+	if err != nil {
+		return errors.Wrapf(err, "failed to create kube client")
+	}
+	o.TektonClient, err = tektonclient.LazyCreateTektonClient(o.TektonClient)
+	if err != nil {
+		return errors.Wrapf(err, "failed to create tekton client")
+	}
+	if o.Name == "" {
+		return options.MissingOption("name")
+	}
+	switch o.Mode {
+	case modeCancel, modeStop, modeCancelRunFinally:
+	default:
+		return errors.Errorf("invalid --mode value %q, must be one of: %s, %s, %s", o.Mode, modeCancel, modeStop, modeCancelRunFinally)
+	}
+	return nil
+}
+
+// Run implements this command
+func (o *Options) Run() error {
+	err := o.Validate()
+	if err != nil {
+		return errors.Wrapf(err, "failed to validate options")
+	}
+
+	ctx := context.Background()
+	pr, err := o.TektonClient.TektonV1beta1().PipelineRuns(o.Namespace).Get(ctx, o.Name, metav1.GetOptions{})
+	if err != nil {
+		return errors.Wrapf(err, "failed to find PipelineRun %s in namespace %s", o.Name, o.Namespace)
+	}
+
+	err = tektonlog.Cancel(ctx, o.TektonClient, o.Namespace, pr, tektonlog.CancelOptions{
+		GracePeriod:    o.GracePeriod,
+		Reason:         o.Reason,
+		Cascade:        o.Cascade,
+		StatusOverride: o.statusForMode(),
+		KubeClient:     o.KubeClient,
+	})
+	if err != nil {
+		return errors.Wrapf(err, "failed to %s PipelineRun %s", o.Mode, o.Name)
+	}
+
+	log.Logger().Infof("%s PipelineRun %s in namespace %s", info(o.Mode), info(o.Name), info(o.Namespace))
+	return nil
+}
+
+// statusForMode maps the --mode flag onto the Tekton PipelineRunSpecStatus value to set
+func (o *Options) statusForMode() pipelineapi.PipelineRunSpecStatus {
+	switch o.Mode {
+	case modeStop:
+		return pipelineapi.PipelineRunSpecStatusStoppedRunFinally
+	case modeCancelRunFinally:
+		return pipelineapi.PipelineRunSpecStatusCancelledRunFinally
+	default:
+		return pipelineapi.PipelineRunSpecStatusCancelled
+	}
+}
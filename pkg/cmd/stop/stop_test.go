@@ -0,0 +1,28 @@
+package stop
+
+import (
+	"testing"
+
+	pipelineapi "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+)
+
+func TestStatusForMode(t *testing.T) {
+	tests := []struct {
+		mode string
+		want pipelineapi.PipelineRunSpecStatus
+	}{
+		{modeCancel, pipelineapi.PipelineRunSpecStatusCancelled},
+		{modeStop, pipelineapi.PipelineRunSpecStatusStoppedRunFinally},
+		{modeCancelRunFinally, pipelineapi.PipelineRunSpecStatusCancelledRunFinally},
+		{"", pipelineapi.PipelineRunSpecStatusCancelled},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.mode, func(t *testing.T) {
+			o := &Options{Mode: tt.mode}
+			if got := o.statusForMode(); got != tt.want {
+				t.Errorf("statusForMode() with Mode %q = %v, want %v", tt.mode, got, tt.want)
+			}
+		})
+	}
+}
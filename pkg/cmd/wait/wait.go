@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/jenkins-x/go-scm/scm"
+	"github.com/jenkins-x/go-scm/scm/factory"
 	jxc "github.com/jenkins-x/jx-api/v3/pkg/client/clientset/versioned"
 	"github.com/jenkins-x/jx-helpers/v3/pkg/kube"
 	"github.com/jenkins-x/jx-helpers/v3/pkg/kube/jxclient"
@@ -35,8 +36,12 @@ type Options struct {
 	Repository          string
 	LighthouseConfigMap string
 	Namespace           string
+	Sha                 string
+	GitContext          string
+	CommitStatus        bool
 	KubeClient          kubernetes.Interface
 	JXClient            jxc.Interface
+	ScmClient           *scm.Client
 }
 
 var (
@@ -76,6 +81,10 @@ func NewCmdPipelineWait() (*cobra.Command, *Options) {
 	cmd.Flags().DurationVarP(&o.WaitDuration, "duration", "", time.Minute*20, "Maximum duration to wait for one or more matching triggers to be setup in Lighthouse. Useful for when a new repository is being imported via GitOps")
 	cmd.Flags().DurationVarP(&o.PollPeriod, "poll-period", "", time.Second*2, "Poll period when waiting for one or more matching triggers to be setup in Lighthouse. Useful for when a new repository is being imported via GitOps")
 
+	cmd.Flags().StringVarP(&o.Sha, "sha", "", "", "The commit sha to wait for a commit status/check on. Requires --commit-status")
+	cmd.Flags().StringVarP(&o.GitContext, "context", "", "", "The commit status/check context (or prefix) to wait for. Requires --commit-status")
+	cmd.Flags().BoolVarP(&o.CommitStatus, "commit-status", "", false, "Also wait for the commit status/check on the git provider for --sha to reach a terminal state")
+
 	return cmd, o
 }
 
@@ -97,6 +106,18 @@ func (o *Options) Validate() error {
 	if o.Repository == "" {
 		return options.MissingOption("repo")
 	}
+
+	if o.CommitStatus {
+		if o.Sha == "" {
+			return options.MissingOption("sha")
+		}
+		if o.ScmClient == nil {
+			o.ScmClient, err = factory.NewClient("", "", "")
+			if err != nil {
+				return errors.Wrapf(err, "failed to create git provider client")
+			}
+		}
+	}
 	return nil
 }
 
@@ -123,9 +144,74 @@ func (o *Options) Run() error {
 	}
 
 	log.Logger().Infof("the repository %s is now setup in lighthouse and has its webhook enabled", info(fullName))
+
+	if o.CommitStatus {
+		err = o.waitForCommitStatus(fullName)
+		if err != nil {
+			return errors.Wrapf(err, "failed to wait for the commit status on %s", o.Sha)
+		}
+	}
 	return nil
 }
 
+// waitForCommitStatus polls the git provider's combined status for o.Sha until every status/check
+// matching o.GitContext (or every status, if o.GitContext is empty) has reached a successful
+// terminal state, returning an error as soon as any of them fails
+func (o *Options) waitForCommitStatus(fullName string) error {
+	ctx := context.Background()
+	end := time.Now().Add(o.WaitDuration)
+	logWaiting := false
+
+	for {
+		combined, _, err := o.ScmClient.Repositories.FindCombinedStatus(ctx, fullName, o.Sha)
+		if err != nil {
+			return errors.Wrapf(err, "failed to find combined status for %s at %s", fullName, o.Sha)
+		}
+
+		done, err := allMatchingStatusesSucceeded(combined.Statuses, o.GitContext)
+		if err != nil {
+			return errors.Wrapf(err, "commit status for %s at %s", fullName, o.Sha)
+		}
+		if done {
+			log.Logger().Infof("commit status %s for %s at %s succeeded", info(o.GitContext), info(fullName), info(o.Sha))
+			return nil
+		}
+
+		if time.Now().After(end) {
+			return errors.Errorf("timed out after %s waiting for commit status %s on %s at %s to reach a terminal state", o.WaitDuration.String(), o.GitContext, fullName, o.Sha)
+		}
+
+		if !logWaiting {
+			logWaiting = true
+			log.Logger().Infof("waiting up to %s for commit status %s on %s at %s to reach a terminal state", info(o.WaitDuration.String()), info(o.GitContext), info(fullName), info(o.Sha))
+		}
+		time.Sleep(o.PollPeriod)
+	}
+}
+
+// allMatchingStatusesSucceeded returns true once every status whose Label matches gitContext (or
+// every status, if gitContext is empty) has reached scm.StateSuccess. It returns an error as soon
+// as any matching status is in a terminal failure state, and false (not yet done) while any
+// matching status is still pending. A combined status with no matching entries at all is
+// considered not yet done, since there is nothing to have succeeded.
+func allMatchingStatusesSucceeded(statuses []*scm.Status, gitContext string) (bool, error) {
+	matched := 0
+	succeeded := 0
+	for _, status := range statuses {
+		if gitContext != "" && !strings.HasPrefix(status.Label, gitContext) {
+			continue
+		}
+		matched++
+		switch status.State {
+		case scm.StateSuccess:
+			succeeded++
+		case scm.StateFailure, scm.StateError:
+			return false, errors.Errorf("%s is in terminal state %s", status.Label, status.State)
+		}
+	}
+	return matched > 0 && succeeded == matched, nil
+}
+
 func (o *Options) waitForRepositoryToBeSetup(kubeClient kubernetes.Interface, ns, fullName string) (bool, error) {
 	end := time.Now().Add(o.WaitDuration)
 	name := o.LighthouseConfigMap
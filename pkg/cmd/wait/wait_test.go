@@ -0,0 +1,88 @@
+package wait
+
+import (
+	"testing"
+
+	"github.com/jenkins-x/go-scm/scm"
+)
+
+func TestAllMatchingStatusesSucceeded(t *testing.T) {
+	tests := []struct {
+		name       string
+		statuses   []*scm.Status
+		gitContext string
+		want       bool
+		wantErr    bool
+	}{
+		{
+			name: "all matching succeed",
+			statuses: []*scm.Status{
+				{Label: "ci/build", State: scm.StateSuccess},
+				{Label: "ci/test", State: scm.StateSuccess},
+			},
+			gitContext: "",
+			want:       true,
+		},
+		{
+			name: "one matching still pending",
+			statuses: []*scm.Status{
+				{Label: "ci/build", State: scm.StateSuccess},
+				{Label: "ci/test", State: scm.StatePending},
+			},
+			gitContext: "",
+			want:       false,
+		},
+		{
+			name: "one matching failed",
+			statuses: []*scm.Status{
+				{Label: "ci/build", State: scm.StateSuccess},
+				{Label: "ci/test", State: scm.StateFailure},
+			},
+			gitContext: "",
+			wantErr:    true,
+		},
+		{
+			name: "one matching errored",
+			statuses: []*scm.Status{
+				{Label: "ci/build", State: scm.StateError},
+			},
+			gitContext: "",
+			wantErr:    true,
+		},
+		{
+			name: "gitContext filters to matching prefix only",
+			statuses: []*scm.Status{
+				{Label: "ci/build", State: scm.StateSuccess},
+				{Label: "ci/test", State: scm.StatePending},
+			},
+			gitContext: "ci/build",
+			want:       true,
+		},
+		{
+			name: "no statuses match gitContext",
+			statuses: []*scm.Status{
+				{Label: "ci/build", State: scm.StateSuccess},
+			},
+			gitContext: "ci/deploy",
+			want:       false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := allMatchingStatusesSucceeded(tt.statuses, tt.gitContext)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("allMatchingStatusesSucceeded() expected an error, got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("allMatchingStatusesSucceeded() returned unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("allMatchingStatusesSucceeded() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
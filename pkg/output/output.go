@@ -0,0 +1,96 @@
+// Package output provides a shared --output flag for pipeline subcommands, so that table-oriented
+// commands like get, activities, pod and getlog can also emit JSON, JSONL or YAML for scripting.
+package output
+
+import (
+	"encoding/json"
+	"io"
+	"reflect"
+
+	"github.com/ghodss/yaml"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+// Format is one of the supported --output values
+type Format string
+
+const (
+	// TableFormat renders the default human readable table, the same as omitting --output
+	TableFormat Format = "table"
+	// JSONFormat renders a single JSON document
+	JSONFormat Format = "json"
+	// JSONLFormat renders one JSON object per line, suitable for streaming output and `jq`
+	JSONLFormat Format = "jsonl"
+	// YAMLFormat renders a single YAML document
+	YAMLFormat Format = "yaml"
+)
+
+// Options holds the --output flag value shared by the get, activities, pod and getlog commands
+type Options struct {
+	Format string
+}
+
+// AddFlag registers the --output flag on the given command
+func (o *Options) AddFlag(cmd *cobra.Command) {
+	cmd.Flags().StringVarP(&o.Format, "output", "", string(TableFormat), "The output format, one of: table, json, jsonl, yaml")
+}
+
+// IsTable returns true if the table renderer should be used, i.e. --output was left at its default
+func (o *Options) IsTable() bool {
+	return o.Format == "" || Format(o.Format) == TableFormat
+}
+
+// Validate checks that the --output value is one of the supported formats
+func (o *Options) Validate() error {
+	switch Format(o.Format) {
+	case "", TableFormat, JSONFormat, JSONLFormat, YAMLFormat:
+		return nil
+	default:
+		return errors.Errorf("invalid --output value %q, must be one of: table, json, jsonl, yaml", o.Format)
+	}
+}
+
+// WriteObject renders a value according to the --output format: a whole JSON or YAML document for
+// json/yaml, or one compact JSON line per element for jsonl. A jsonl obj that isn't a slice/array is
+// written as a single line, the same as WriteLine.
+func (o *Options) WriteObject(out io.Writer, obj interface{}) error {
+	switch Format(o.Format) {
+	case YAMLFormat:
+		data, err := yaml.Marshal(obj)
+		if err != nil {
+			return errors.Wrapf(err, "failed to marshal %T to YAML", obj)
+		}
+		_, err = out.Write(data)
+		return err
+	case JSONLFormat:
+		v := reflect.ValueOf(obj)
+		if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
+			return o.WriteLine(out, obj)
+		}
+		for i := 0; i < v.Len(); i++ {
+			if err := o.WriteLine(out, v.Index(i).Interface()); err != nil {
+				return err
+			}
+		}
+		return nil
+	default:
+		data, err := json.MarshalIndent(obj, "", "  ")
+		if err != nil {
+			return errors.Wrapf(err, "failed to marshal %T to JSON", obj)
+		}
+		_, err = out.Write(append(data, '\n'))
+		return err
+	}
+}
+
+// WriteLine renders a single value as one JSON line, used by --output jsonl to stream events such
+// as log lines or activity state transitions
+func (o *Options) WriteLine(out io.Writer, obj interface{}) error {
+	data, err := json.Marshal(obj)
+	if err != nil {
+		return errors.Wrapf(err, "failed to marshal %T to JSON", obj)
+	}
+	_, err = out.Write(append(data, '\n'))
+	return err
+}
@@ -0,0 +1,140 @@
+package output
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestOptionsValidate(t *testing.T) {
+	tests := []struct {
+		format  string
+		wantErr bool
+	}{
+		{"", false},
+		{"table", false},
+		{"json", false},
+		{"jsonl", false},
+		{"yaml", false},
+		{"xml", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			o := &Options{Format: tt.format}
+			err := o.Validate()
+			if tt.wantErr && err == nil {
+				t.Errorf("Validate() with format %q expected an error, got nil", tt.format)
+			}
+			if !tt.wantErr && err != nil {
+				t.Errorf("Validate() with format %q returned unexpected error: %v", tt.format, err)
+			}
+		})
+	}
+}
+
+func TestOptionsIsTable(t *testing.T) {
+	tests := []struct {
+		format string
+		want   bool
+	}{
+		{"", true},
+		{"table", true},
+		{"json", false},
+		{"jsonl", false},
+		{"yaml", false},
+	}
+
+	for _, tt := range tests {
+		o := &Options{Format: tt.format}
+		if got := o.IsTable(); got != tt.want {
+			t.Errorf("IsTable() with format %q = %v, want %v", tt.format, got, tt.want)
+		}
+	}
+}
+
+func TestOptionsWriteObject(t *testing.T) {
+	type thing struct {
+		Name string `json:"name"`
+	}
+
+	tests := []struct {
+		format   string
+		wantSubs []string
+	}{
+		{"json", []string{`"name"`, `"foo"`}},
+		{"yaml", []string{"name:", "foo"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			var buf bytes.Buffer
+			o := &Options{Format: tt.format}
+			if err := o.WriteObject(&buf, thing{Name: "foo"}); err != nil {
+				t.Fatalf("WriteObject() returned error: %v", err)
+			}
+			for _, sub := range tt.wantSubs {
+				if !strings.Contains(buf.String(), sub) {
+					t.Errorf("WriteObject() output %q does not contain %q", buf.String(), sub)
+				}
+			}
+		})
+	}
+}
+
+func TestOptionsWriteObjectJSONL(t *testing.T) {
+	type thing struct {
+		Name string `json:"name"`
+	}
+
+	var buf bytes.Buffer
+	o := &Options{Format: "jsonl"}
+	things := []thing{{Name: "foo"}, {Name: "bar"}}
+	if err := o.WriteObject(&buf, things); err != nil {
+		t.Fatalf("WriteObject() returned error: %v", err)
+	}
+
+	lines := strings.Split(strings.TrimRight(buf.String(), "\n"), "\n")
+	if len(lines) != len(things) {
+		t.Fatalf("expected %d lines, got %d: %q", len(things), len(lines), buf.String())
+	}
+	for i, line := range lines {
+		want := `{"name":"` + things[i].Name + `"}`
+		if line != want {
+			t.Errorf("line %d = %q, want %q", i, line, want)
+		}
+	}
+}
+
+func TestOptionsWriteObjectJSONLNonSlice(t *testing.T) {
+	type thing struct {
+		Name string `json:"name"`
+	}
+
+	var buf bytes.Buffer
+	o := &Options{Format: "jsonl"}
+	if err := o.WriteObject(&buf, thing{Name: "foo"}); err != nil {
+		t.Fatalf("WriteObject() returned error: %v", err)
+	}
+	if got, want := buf.String(), "{\"name\":\"foo\"}\n"; got != want {
+		t.Errorf("WriteObject() on a non-slice with jsonl format = %q, want %q", got, want)
+	}
+}
+
+func TestOptionsWriteLine(t *testing.T) {
+	type thing struct {
+		Name string `json:"name"`
+	}
+
+	var buf bytes.Buffer
+	o := &Options{Format: "jsonl"}
+	if err := o.WriteLine(&buf, thing{Name: "foo"}); err != nil {
+		t.Fatalf("WriteLine() returned error: %v", err)
+	}
+	if !strings.HasSuffix(buf.String(), "\n") {
+		t.Errorf("WriteLine() output %q should end with a newline", buf.String())
+	}
+	if !strings.Contains(buf.String(), `"name":"foo"`) {
+		t.Errorf("WriteLine() output %q does not contain the expected field", buf.String())
+	}
+}
@@ -0,0 +1,32 @@
+// Package pipelineactivities provides shared helpers for listing and printing PipelineActivities,
+// used by both the "get" and "activities" commands.
+package pipelineactivities
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	jxc "github.com/jenkins-x/jx-api/v3/pkg/client/clientset/versioned"
+	"github.com/jenkins-x/jx-pipeline/pkg/output"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ListAndWrite lists the PipelineActivities in ns and writes them to out, either as a table of
+// name/status or via the given output.Options format
+func ListAndWrite(ctx context.Context, jxClient jxc.Interface, ns string, out io.Writer, o output.Options) error {
+	activities, err := jxClient.JenkinsV1().PipelineActivities(ns).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return errors.Wrapf(err, "failed to list PipelineActivities in namespace %s", ns)
+	}
+
+	if !o.IsTable() {
+		return o.WriteObject(out, activities.Items)
+	}
+
+	for _, activity := range activities.Items {
+		fmt.Fprintf(out, "%s\t%s\n", activity.Name, activity.Spec.Status)
+	}
+	return nil
+}
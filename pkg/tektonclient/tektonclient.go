@@ -0,0 +1,26 @@
+// Package tektonclient provides a lazy-create helper for the Tekton Pipeline clientset, mirroring
+// the jx-helpers kube/jxclient and kube.LazyCreateKubeClientAndNamespace conventions used for the
+// Kubernetes and jx clients.
+package tektonclient
+
+import (
+	"github.com/jenkins-x/jx-helpers/v3/pkg/kube"
+	"github.com/pkg/errors"
+	tektonclient "github.com/tektoncd/pipeline/pkg/client/clientset/versioned"
+)
+
+// LazyCreateTektonClient lazily creates a Tekton client if one hasn't already been configured
+func LazyCreateTektonClient(client tektonclient.Interface) (tektonclient.Interface, error) {
+	if client != nil {
+		return client, nil
+	}
+	cfg, err := kube.LoadConfig()
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to load Kubernetes configuration")
+	}
+	client, err = tektonclient.NewForConfig(cfg)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error building tekton client")
+	}
+	return client, nil
+}
@@ -0,0 +1,140 @@
+package tektonlog
+
+import (
+	"context"
+	"time"
+
+	"github.com/jenkins-x/jx-logging/v3/pkg/log"
+	"github.com/pkg/errors"
+	pipelineapi "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	tektonclient "github.com/tektoncd/pipeline/pkg/client/clientset/versioned"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// CancelReasonAnnotation records the human-readable reason a PipelineRun was cancelled or stopped
+const CancelReasonAnnotation = "jenkins-x.io/cancel-reason"
+
+// CancelOptions configures Cancel
+type CancelOptions struct {
+	// GracePeriod, if non-zero, is how long to wait for the PipelineRun to reach a terminal state
+	// after requesting cancellation before force-deleting any pods still running
+	GracePeriod time.Duration
+	// Reason is recorded on the PipelineRun as the CancelReasonAnnotation
+	Reason string
+	// Cascade, when true, explicitly cancels the PipelineRun's child TaskRuns too, for controller
+	// versions that don't propagate cancellation to them on their own
+	Cascade bool
+	// StatusOverride is the PipelineRunSpec.Status value to set. Defaults to
+	// PipelineRunSpecStatusCancelled. Set it to PipelineRunSpecStatusCancelledRunFinally or
+	// PipelineRunSpecStatusStoppedRunFinally to let already-running finally tasks complete.
+	StatusOverride pipelineapi.PipelineRunSpecStatus
+	// KubeClient is required when GracePeriod is set, so stuck pods can be force-deleted
+	KubeClient kubernetes.Interface
+}
+
+// Cancel requests cancellation of a PipelineRun, recording the reason as an annotation, optionally
+// waiting for it to reach a terminal state and force-deleting stuck pods if it doesn't, and
+// optionally cascading the cancellation to its child TaskRuns for controllers that don't do so
+// automatically.
+func Cancel(ctx context.Context, tektonClient tektonclient.Interface, ns string, pr *pipelineapi.PipelineRun, opts CancelOptions) error {
+	status := opts.StatusOverride
+	if status == "" {
+		status = pipelineapi.PipelineRunSpecStatusCancelled
+	}
+
+	pr.Spec.Status = status
+	if opts.Reason != "" {
+		if pr.Annotations == nil {
+			pr.Annotations = map[string]string{}
+		}
+		pr.Annotations[CancelReasonAnnotation] = opts.Reason
+	}
+
+	updated, err := tektonClient.TektonV1beta1().PipelineRuns(ns).Update(ctx, pr, metav1.UpdateOptions{})
+	if err != nil {
+		return errors.Wrapf(err, "failed to update PipelineRun %s in namespace %s to status %s", pr.Name, ns, status)
+	}
+
+	if opts.Cascade {
+		if err := cascadeToTaskRuns(ctx, tektonClient, ns, updated); err != nil {
+			return errors.Wrapf(err, "failed to cascade %s to the TaskRuns of PipelineRun %s", status, pr.Name)
+		}
+	}
+
+	if opts.GracePeriod <= 0 {
+		return nil
+	}
+	return waitForTerminalOrForceDelete(ctx, tektonClient, opts.KubeClient, ns, updated.Name, opts.GracePeriod)
+}
+
+// cascadeToTaskRuns requests cancellation of every TaskRun owned by the PipelineRun, for
+// controller versions that don't propagate PipelineRun cancellation to its TaskRuns on their own.
+// TaskRunSpecStatus only has a single cancelled value, unlike PipelineRunSpecStatus, since
+// TaskRuns have no finally tasks to let run to completion.
+func cascadeToTaskRuns(ctx context.Context, tektonClient tektonclient.Interface, ns string, pr *pipelineapi.PipelineRun) error {
+	for name := range pr.Status.TaskRuns {
+		tr, err := tektonClient.TektonV1beta1().TaskRuns(ns).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			return errors.Wrapf(err, "failed to get TaskRun %s in namespace %s", name, ns)
+		}
+		tr.Spec.Status = pipelineapi.TaskRunSpecStatusCancelled
+		_, err = tektonClient.TektonV1beta1().TaskRuns(ns).Update(ctx, tr, metav1.UpdateOptions{})
+		if err != nil {
+			return errors.Wrapf(err, "failed to cancel TaskRun %s in namespace %s", name, ns)
+		}
+	}
+	return nil
+}
+
+// waitForTerminalOrForceDelete polls the PipelineRun until it reaches a terminal state or
+// gracePeriod elapses, at which point it force-deletes any of its TaskRun pods still running
+func waitForTerminalOrForceDelete(ctx context.Context, tektonClient tektonclient.Interface, kubeClient kubernetes.Interface, ns, name string, gracePeriod time.Duration) error {
+	end := time.Now().Add(gracePeriod)
+
+	for {
+		pr, err := tektonClient.TektonV1beta1().PipelineRuns(ns).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return errors.Wrapf(err, "failed to get PipelineRun %s in namespace %s", name, ns)
+		}
+		if PipelineRunIsComplete(pr) {
+			return nil
+		}
+
+		if time.Now().After(end) {
+			return forceDeletePods(ctx, kubeClient, ns, pr)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(time.Second):
+		}
+	}
+}
+
+// forceDeletePods force-deletes every pod still backing the PipelineRun's TaskRuns, used when a
+// PipelineRun doesn't reach a terminal state within its grace period after being cancelled
+func forceDeletePods(ctx context.Context, kubeClient kubernetes.Interface, ns string, pr *pipelineapi.PipelineRun) error {
+	if kubeClient == nil {
+		return errors.Errorf("no KubeClient configured to force-delete stuck pods for PipelineRun %s", pr.Name)
+	}
+
+	var gracePeriodSeconds int64
+	for name, trStatus := range pr.Status.TaskRuns {
+		if trStatus == nil || trStatus.Status == nil || trStatus.Status.PodName == "" {
+			continue
+		}
+		podName := trStatus.Status.PodName
+		err := kubeClient.CoreV1().Pods(ns).Delete(ctx, podName, metav1.DeleteOptions{GracePeriodSeconds: &gracePeriodSeconds})
+		if err != nil && !apierrors.IsNotFound(err) {
+			return errors.Wrapf(err, "failed to force-delete pod %s for TaskRun %s", podName, name)
+		}
+		log.Logger().Warnf("force-deleted stuck pod %s for TaskRun %s of PipelineRun %s", podName, name, pr.Name)
+	}
+	return nil
+}
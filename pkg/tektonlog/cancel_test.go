@@ -0,0 +1,85 @@
+package tektonlog
+
+import (
+	"context"
+	"testing"
+
+	pipelineapi "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	tektonfake "github.com/tektoncd/pipeline/pkg/client/clientset/versioned/fake"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestCancel(t *testing.T) {
+	tests := []struct {
+		name           string
+		opts           CancelOptions
+		wantStatus     pipelineapi.PipelineRunSpecStatus
+		wantTaskStatus pipelineapi.TaskRunSpecStatus
+		wantReason     string
+	}{
+		{
+			name:       "defaults to cancelled",
+			opts:       CancelOptions{},
+			wantStatus: pipelineapi.PipelineRunSpecStatusCancelled,
+		},
+		{
+			name:       "stop mode",
+			opts:       CancelOptions{StatusOverride: pipelineapi.PipelineRunSpecStatusStoppedRunFinally},
+			wantStatus: pipelineapi.PipelineRunSpecStatusStoppedRunFinally,
+		},
+		{
+			name:       "records the reason annotation",
+			opts:       CancelOptions{Reason: "bad commit"},
+			wantStatus: pipelineapi.PipelineRunSpecStatusCancelled,
+			wantReason: "bad commit",
+		},
+		{
+			name:           "cascades cancellation to task runs",
+			opts:           CancelOptions{Cascade: true},
+			wantStatus:     pipelineapi.PipelineRunSpecStatusCancelled,
+			wantTaskStatus: pipelineapi.TaskRunSpecStatusCancelled,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ns := "jx"
+			pr := &pipelineapi.PipelineRun{
+				ObjectMeta: metav1.ObjectMeta{Name: "mypr-myrepo-pr-42-1", Namespace: ns},
+				Status: pipelineapi.PipelineRunStatus{
+					PipelineRunStatusFields: pipelineapi.PipelineRunStatusFields{
+						TaskRuns: map[string]*pipelineapi.PipelineRunTaskRunStatus{
+							"mypr-myrepo-pr-42-1-build": {PipelineTaskName: "build"},
+						},
+					},
+				},
+			}
+			tr := &pipelineapi.TaskRun{ObjectMeta: metav1.ObjectMeta{Name: "mypr-myrepo-pr-42-1-build", Namespace: ns}}
+
+			client := tektonfake.NewSimpleClientset(pr, tr)
+
+			if err := Cancel(context.Background(), client, ns, pr, tt.opts); err != nil {
+				t.Fatalf("Cancel() returned error: %v", err)
+			}
+
+			updated, err := client.TektonV1beta1().PipelineRuns(ns).Get(context.Background(), pr.Name, metav1.GetOptions{})
+			if err != nil {
+				t.Fatalf("failed to get updated PipelineRun: %v", err)
+			}
+			if updated.Spec.Status != tt.wantStatus {
+				t.Errorf("PipelineRun status = %v, want %v", updated.Spec.Status, tt.wantStatus)
+			}
+			if tt.wantReason != "" && updated.Annotations[CancelReasonAnnotation] != tt.wantReason {
+				t.Errorf("reason annotation = %q, want %q", updated.Annotations[CancelReasonAnnotation], tt.wantReason)
+			}
+
+			updatedTR, err := client.TektonV1beta1().TaskRuns(ns).Get(context.Background(), tr.Name, metav1.GetOptions{})
+			if err != nil {
+				t.Fatalf("failed to get TaskRun: %v", err)
+			}
+			if updatedTR.Spec.Status != tt.wantTaskStatus {
+				t.Errorf("TaskRun status = %v, want %v", updatedTR.Spec.Status, tt.wantTaskStatus)
+			}
+		})
+	}
+}
@@ -1,14 +1,13 @@
 package tektonlog
 
 import (
-	"context"
-
-	"github.com/pkg/errors"
 	pipelineapi "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
-	tektonclient "github.com/tektoncd/pipeline/pkg/client/clientset/versioned"
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
+// LabelPipelineRunName is the label Tekton adds to every Pod it creates on behalf of a TaskRun,
+// set to the name of the owning PipelineRun.
+const LabelPipelineRunName = "tekton.dev/pipelineRun"
+
 // PipelineType is used to differentiate between actual build pipelines and pipelines to create the build pipelines,
 // aka meta pipelines.
 type PipelineType int
@@ -40,14 +39,3 @@ func PipelineRunIsNotPending(pr *pipelineapi.PipelineRun) bool {
 func PipelineRunIsComplete(pr *pipelineapi.PipelineRun) bool {
 	return pr.Status.CompletionTime != nil
 }
-
-// CancelPipelineRun cancels a Pipeline
-func CancelPipelineRun(tektonClient tektonclient.Interface, ns string, pr *pipelineapi.PipelineRun) error {
-	ctx := context.Background()
-	pr.Spec.Status = pipelineapi.PipelineRunSpecStatusCancelled
-	_, err := tektonClient.TektonV1beta1().PipelineRuns(ns).Update(ctx, pr, metav1.UpdateOptions{})
-	if err != nil {
-		return errors.Wrapf(err, "failed to update PipelineRun %s in namespace %s to mark it as cancelled", pr.Name, ns)
-	}
-	return nil
-}
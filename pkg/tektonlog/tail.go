@@ -0,0 +1,368 @@
+package tektonlog
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/pkg/errors"
+	pipelineapi "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	tektonclient "github.com/tektoncd/pipeline/pkg/client/clientset/versioned"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// Level is the severity of a LogEntry line
+type Level string
+
+const (
+	// LevelInfo is a regular log line
+	LevelInfo Level = "info"
+	// LevelWarn is emitted when a stream reconnects after a transient error
+	LevelWarn Level = "warn"
+	// LevelError is emitted when a stream fails permanently
+	LevelError Level = "error"
+)
+
+// LogEntry is a single line of output from a step container, tagged with enough
+// context to reconstruct which Task/Step/Pod/Container it came from
+type LogEntry struct {
+	Task      string
+	Step      string
+	Pod       string
+	Container string
+	Time      time.Time
+	Line      string
+	Level     Level
+}
+
+// TailOptions configures TailPipelineRunLogs
+type TailOptions struct {
+	// Follow keeps watching the PipelineRun for newly scheduled TaskRuns and keeps each
+	// container's log stream open for new lines. When false, only the TaskRuns already present
+	// are tailed, each container's existing log output is read once, and the channel closes as
+	// soon as that snapshot has drained.
+	Follow bool
+	// PollPeriod is how often to check whether new TaskRuns have been scheduled
+	PollPeriod time.Duration
+	// InitialBackoff is the first retry delay used when a log stream fails
+	InitialBackoff time.Duration
+	// MaxBackoff caps the exponential backoff applied to stream reconnects
+	MaxBackoff time.Duration
+	// BufferSize is the capacity of each container's ring buffer. Once full, the oldest
+	// buffered line is dropped so reading from the pod's log stream never blocks.
+	BufferSize int
+	// DrainPeriod is how often each container's ring buffer is drained into the merged
+	// output channel.
+	DrainPeriod time.Duration
+}
+
+func (o *TailOptions) defaults() {
+	if o.PollPeriod <= 0 {
+		o.PollPeriod = time.Second * 2
+	}
+	if o.InitialBackoff <= 0 {
+		o.InitialBackoff = time.Second
+	}
+	if o.MaxBackoff <= 0 {
+		o.MaxBackoff = time.Second * 30
+	}
+	if o.BufferSize <= 0 {
+		o.BufferSize = 100
+	}
+	if o.DrainPeriod <= 0 {
+		o.DrainPeriod = time.Millisecond * 200
+	}
+}
+
+// TailPipelineRunLogs follows a PipelineRun across all of its TaskRun pods and step containers,
+// merging their logs into a single channel. Within a TaskRun, steps are tailed one at a time in
+// the order they appear in the TaskRun's status, so a later step's lines never interleave with an
+// earlier one's. It watches the PipelineRun so that newly scheduled TaskRuns are picked up
+// mid-run, and transparently reconnects streams that fail with transient errors using exponential
+// backoff. Each container reads into its own bounded ring buffer, which a drain goroutine fans
+// into the merged channel, so a stalled consumer or full output channel never blocks the
+// underlying pod log read. The returned channel is closed once PipelineRunIsComplete returns true
+// for the PipelineRun and every TaskRun's tailer has finished.
+func TailPipelineRunLogs(ctx context.Context, tektonClient tektonclient.Interface, kubeClient kubernetes.Interface, ns, prName string, opts TailOptions) (<-chan LogEntry, error) {
+	opts.defaults()
+
+	out := make(chan LogEntry, opts.BufferSize)
+
+	t := &tailer{
+		ctx:          ctx,
+		tektonClient: tektonClient,
+		ns:           ns,
+		prName:       prName,
+		opts:         opts,
+		out:          out,
+		started:      map[string]bool{},
+		getPodLogs: func(pod, container string) (io.ReadCloser, error) {
+			return kubeClient.CoreV1().Pods(ns).GetLogs(pod, &corev1.PodLogOptions{
+				Container: container,
+				Follow:    opts.Follow,
+			}).Stream(ctx)
+		},
+	}
+
+	go t.run()
+
+	return out, nil
+}
+
+type tailer struct {
+	ctx          context.Context
+	tektonClient tektonclient.Interface
+	ns           string
+	prName       string
+	opts         TailOptions
+	out          chan LogEntry
+	started      map[string]bool
+	wg           sync.WaitGroup
+	active       int32
+	// getPodLogs opens a log stream for one container, overridable in tests so the concurrency and
+	// reconnect logic in tailStep can be exercised without a real Kubernetes API server
+	getPodLogs func(pod, container string) (io.ReadCloser, error)
+}
+
+// send forwards a LogEntry to the merged output channel, giving up if ctx is done so a stalled
+// consumer can never leak a tailer goroutine
+func (t *tailer) send(entry LogEntry) {
+	select {
+	case t.out <- entry:
+	case <-t.ctx.Done():
+	}
+}
+
+// run polls the PipelineRun, starting a tailer for each TaskRun it hasn't seen yet, and closes the
+// output channel once the run is complete and every tailer has finished. When Follow is false it
+// only considers the TaskRuns already present on the first fetch, and returns as soon as their
+// existing logs have drained rather than waiting for the PipelineRun itself to complete.
+func (t *tailer) run() {
+	defer close(t.out)
+
+	for {
+		pr, err := t.tektonClient.TektonV1beta1().PipelineRuns(t.ns).Get(t.ctx, t.prName, metav1.GetOptions{})
+		if err != nil {
+			t.send(LogEntry{Level: LevelError, Time: now(), Line: errors.Wrapf(err, "failed to get PipelineRun %s", t.prName).Error()})
+			return
+		}
+
+		t.startNewTaskRunTailers(pr)
+
+		if !t.opts.Follow {
+			t.wg.Wait()
+			return
+		}
+
+		complete := PipelineRunIsComplete(pr)
+		if complete && atomic.LoadInt32(&t.active) == 0 {
+			return
+		}
+
+		select {
+		case <-t.ctx.Done():
+			t.wg.Wait()
+			return
+		case <-time.After(t.opts.PollPeriod):
+		}
+	}
+}
+
+// startNewTaskRunTailers starts one goroutine per TaskRun that hasn't already been started, which
+// tails that TaskRun's steps one at a time in order
+func (t *tailer) startNewTaskRunTailers(pr *pipelineapi.PipelineRun) {
+	for taskRunName, trStatus := range pr.Status.TaskRuns {
+		if trStatus == nil || trStatus.Status == nil || t.started[taskRunName] {
+			continue
+		}
+		podName := trStatus.Status.PodName
+		if podName == "" || len(trStatus.Status.Steps) == 0 {
+			continue
+		}
+		t.started[taskRunName] = true
+		t.wg.Add(1)
+		atomic.AddInt32(&t.active, 1)
+
+		taskName := trStatus.PipelineTaskName
+		steps := trStatus.Status.Steps
+		go t.tailTaskRun(taskName, podName, steps)
+	}
+}
+
+// tailTaskRun tails a TaskRun's step containers one at a time, in the order they're listed in the
+// TaskRun's status, so lines from a later step never interleave with an earlier one's
+func (t *tailer) tailTaskRun(task, pod string, steps []pipelineapi.StepState) {
+	defer t.wg.Done()
+	defer atomic.AddInt32(&t.active, -1)
+
+	for _, step := range steps {
+		if t.ctx.Err() != nil {
+			return
+		}
+		t.tailStep(task, step.Name, pod, step.Container)
+	}
+}
+
+// tailStep streams a single container's logs, via a bounded ring buffer, into the output channel.
+// With Follow it reconnects with exponential backoff until the container's stream ends cleanly,
+// since a step that hasn't started yet or is still pulling its image looks the same as a transient
+// error. Without Follow there is no "eventually" to wait for - the command is meant to print the
+// existing logs once and exit - so a failed stream is reported once and tailStep returns rather
+// than retrying forever.
+func (t *tailer) tailStep(task, step, pod, container string) {
+	ring := newRingBuffer(t.opts.BufferSize)
+
+	drainDone := make(chan struct{})
+	go func() {
+		defer close(drainDone)
+		t.drainRing(ring)
+	}()
+
+	backoff := t.opts.InitialBackoff
+retry:
+	for {
+		err := t.streamContainer(task, step, pod, container, ring)
+		if err == nil {
+			break
+		}
+		if t.ctx.Err() != nil {
+			break
+		}
+		if !t.opts.Follow {
+			ring.push(LogEntry{Task: task, Step: step, Pod: pod, Container: container, Time: now(), Level: LevelError,
+				Line: errors.Wrapf(err, "failed to read logs for %s/%s", pod, container).Error()})
+			break
+		}
+
+		ring.push(LogEntry{Task: task, Step: step, Pod: pod, Container: container, Time: now(), Level: LevelWarn,
+			Line: errors.Wrapf(err, "reconnecting to logs for %s/%s after error", pod, container).Error()})
+
+		select {
+		case <-t.ctx.Done():
+			break retry
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+		if backoff > t.opts.MaxBackoff {
+			backoff = t.opts.MaxBackoff
+		}
+	}
+
+	ring.close()
+	<-drainDone
+}
+
+// drainRing periodically flushes a container's ring buffer into the merged output channel until
+// the ring buffer is closed and empty
+func (t *tailer) drainRing(ring *ringBuffer) {
+	ticker := time.NewTicker(t.opts.DrainPeriod)
+	defer ticker.Stop()
+
+	for {
+		for _, entry := range ring.drain() {
+			t.send(entry)
+		}
+		if ring.isClosed() {
+			return
+		}
+
+		select {
+		case <-t.ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// streamContainer opens a log stream for one container and pushes every line into ring, returning
+// nil once the stream ends cleanly (EOF, the container has terminated)
+func (t *tailer) streamContainer(task, step, pod, container string, ring *ringBuffer) error {
+	stream, err := t.getPodLogs(pod, container)
+	if err != nil {
+		return err
+	}
+	defer stream.Close() //nolint:errcheck
+
+	scanner := bufio.NewScanner(stream)
+	for scanner.Scan() {
+		ring.push(LogEntry{
+			Task:      task,
+			Step:      step,
+			Pod:       pod,
+			Container: container,
+			Time:      now(),
+			Line:      scanner.Text(),
+			Level:     LevelInfo,
+		})
+	}
+	if err := scanner.Err(); err != nil && err != io.EOF {
+		return err
+	}
+	return nil
+}
+
+func now() time.Time {
+	return time.Now()
+}
+
+// ringBuffer is a fixed-capacity, drop-oldest queue of LogEntry values. Pushing never blocks: once
+// full, the oldest buffered entry is overwritten. This keeps a slow consumer from ever blocking
+// the goroutine reading the pod's log stream.
+type ringBuffer struct {
+	mu      sync.Mutex
+	entries []LogEntry
+	next    int
+	count   int
+	closed  bool
+}
+
+func newRingBuffer(size int) *ringBuffer {
+	return &ringBuffer{entries: make([]LogEntry, size)}
+}
+
+// push adds an entry, overwriting the oldest one if the buffer is already full
+func (r *ringBuffer) push(entry LogEntry) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.entries[r.next] = entry
+	r.next = (r.next + 1) % len(r.entries)
+	if r.count < len(r.entries) {
+		r.count++
+	}
+}
+
+// drain returns every buffered entry, oldest first, and empties the buffer
+func (r *ringBuffer) drain() []LogEntry {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.count == 0 {
+		return nil
+	}
+	out := make([]LogEntry, 0, r.count)
+	start := (r.next - r.count + len(r.entries)) % len(r.entries)
+	for i := 0; i < r.count; i++ {
+		out = append(out, r.entries[(start+i)%len(r.entries)])
+	}
+	r.count = 0
+	return out
+}
+
+// close marks the ring buffer as done, so drainRing knows to stop after the final drain
+func (r *ringBuffer) close() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.closed = true
+}
+
+func (r *ringBuffer) isClosed() bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.closed
+}
@@ -0,0 +1,174 @@
+package tektonlog
+
+import (
+	"context"
+	"errors"
+	"io"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRingBufferDropsOldestWhenFull(t *testing.T) {
+	r := newRingBuffer(2)
+	r.push(LogEntry{Line: "1"})
+	r.push(LogEntry{Line: "2"})
+	r.push(LogEntry{Line: "3"})
+
+	got := r.drain()
+	if len(got) != 2 {
+		t.Fatalf("expected 2 entries after overflow, got %d", len(got))
+	}
+	if got[0].Line != "2" || got[1].Line != "3" {
+		t.Errorf("expected the oldest entry to be dropped, got %v, %v", got[0].Line, got[1].Line)
+	}
+}
+
+func TestRingBufferDrainEmptiesAndReturnsNilWhenEmpty(t *testing.T) {
+	r := newRingBuffer(4)
+	r.push(LogEntry{Line: "1"})
+
+	if got := r.drain(); len(got) != 1 {
+		t.Fatalf("expected 1 entry, got %d", len(got))
+	}
+	if got := r.drain(); got != nil {
+		t.Errorf("expected drain of an already-drained buffer to return nil, got %v", got)
+	}
+}
+
+func TestRingBufferCloseIsClosed(t *testing.T) {
+	r := newRingBuffer(1)
+	if r.isClosed() {
+		t.Fatal("expected a new ringBuffer to not be closed")
+	}
+	r.close()
+	if !r.isClosed() {
+		t.Error("expected isClosed to be true after close")
+	}
+}
+
+func TestTailerSendGivesUpOnContextDone(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	tr := &tailer{ctx: ctx, out: make(chan LogEntry)}
+
+	done := make(chan struct{})
+	go func() {
+		tr.send(LogEntry{Line: "should not block"})
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("send() blocked forever on an unbuffered channel with no reader, despite a cancelled context")
+	}
+}
+
+// fakeReadCloser is a minimal io.ReadCloser for feeding fixed content through streamContainer
+type fakeReadCloser struct {
+	*stringsReader
+}
+
+func (f *fakeReadCloser) Close() error { return nil }
+
+type stringsReader struct {
+	data string
+	pos  int
+}
+
+func (s *stringsReader) Read(p []byte) (int, error) {
+	if s.pos >= len(s.data) {
+		return 0, io.EOF
+	}
+	n := copy(p, s.data[s.pos:])
+	s.pos += n
+	return n, nil
+}
+
+func newFakeStream(content string) io.ReadCloser {
+	return &fakeReadCloser{&stringsReader{data: content}}
+}
+
+func newTestTailer(opts TailOptions, getPodLogs func(pod, container string) (io.ReadCloser, error)) *tailer {
+	opts.defaults()
+	return &tailer{
+		ctx:        context.Background(),
+		opts:       opts,
+		out:        make(chan LogEntry, opts.BufferSize),
+		started:    map[string]bool{},
+		getPodLogs: getPodLogs,
+	}
+}
+
+func TestTailStepWithoutFollowStopsAfterOneError(t *testing.T) {
+	var calls int32
+	tr := newTestTailer(TailOptions{Follow: false, InitialBackoff: time.Millisecond}, func(pod, container string) (io.ReadCloser, error) {
+		atomic.AddInt32(&calls, 1)
+		return nil, errors.New("container not found")
+	})
+
+	done := make(chan struct{})
+	go func() {
+		tr.tailStep("build", "compile", "mypod", "step-compile")
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("tailStep() with Follow=false did not return after a single stream error")
+	}
+
+	if got := atomic.LoadInt32(&calls); got != 1 {
+		t.Errorf("expected exactly 1 call to getPodLogs without Follow, got %d", got)
+	}
+
+	var entries []LogEntry
+	close(tr.out)
+	for entry := range tr.out {
+		entries = append(entries, entry)
+	}
+	if len(entries) != 1 || entries[0].Level != LevelError {
+		t.Errorf("expected a single LevelError entry reporting the failure, got %v", entries)
+	}
+}
+
+func TestTailStepWithFollowReconnectsAfterError(t *testing.T) {
+	var calls int32
+	tr := newTestTailer(TailOptions{Follow: true, InitialBackoff: time.Millisecond, MaxBackoff: time.Millisecond * 2}, func(pod, container string) (io.ReadCloser, error) {
+		n := atomic.AddInt32(&calls, 1)
+		if n == 1 {
+			return nil, errors.New("transient error")
+		}
+		return newFakeStream("line one\nline two\n"), nil
+	})
+
+	done := make(chan struct{})
+	go func() {
+		tr.tailStep("build", "compile", "mypod", "step-compile")
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("tailStep() with Follow=true never completed after reconnecting")
+	}
+
+	if got := atomic.LoadInt32(&calls); got != 2 {
+		t.Errorf("expected getPodLogs to be called twice (one failure, one success), got %d", got)
+	}
+
+	var lines []string
+	close(tr.out)
+	for entry := range tr.out {
+		if entry.Level == LevelInfo {
+			lines = append(lines, entry.Line)
+		}
+	}
+	if len(lines) != 2 || lines[0] != "line one" || lines[1] != "line two" {
+		t.Errorf("expected the two lines from the reconnected stream in order, got %v", lines)
+	}
+}